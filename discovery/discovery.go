@@ -0,0 +1,70 @@
+// Package discovery finds USB ESC/POS printers attached to the local
+// machine by reading their IEEE-1284 Device ID over the Linux lp driver,
+// so callers don't need to know the device path or character encoding of
+// a printer in advance.
+package discovery
+
+import "github.com/yuebaibai/go-escpos"
+
+// ErrNoDevicesFound is returned by Discover when no USB printers respond,
+// and on platforms other than Linux where discovery isn't implemented.
+var ErrNoDevicesFound = escpos.ErrorNoDevicesFound
+
+// DeviceInfo is a USB printer found by Discover, identified by its
+// IEEE-1284 Device ID string.
+type DeviceInfo struct {
+	Path         string
+	Manufacturer string
+	Model        string
+	Commands     string
+	Serial       string
+}
+
+// parseDeviceID splits an IEEE-1284 Device ID string's semicolon-separated
+// "KEY:value" pairs into a DeviceInfo for path.
+func parseDeviceID(path, raw string) DeviceInfo {
+	info := DeviceInfo{Path: path}
+
+	for _, field := range splitFields(raw) {
+		key, value, ok := splitPair(field)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "MFG", "MANUFACTURER":
+			info.Manufacturer = value
+		case "MDL", "MODEL":
+			info.Model = value
+		case "CMD", "COMMAND SET":
+			info.Commands = value
+		case "SN", "SERIALNUMBER":
+			info.Serial = value
+		}
+	}
+
+	return info
+}
+
+func splitFields(raw string) []string {
+	var fields []string
+	start := 0
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == ';' {
+			fields = append(fields, raw[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(raw) {
+		fields = append(fields, raw[start:])
+	}
+	return fields
+}
+
+func splitPair(field string) (key, value string, ok bool) {
+	for i := 0; i < len(field); i++ {
+		if field[i] == ':' {
+			return field[:i], field[i+1:], true
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,76 @@
+//go:build linux
+
+package discovery
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+// lpIOCGetDeviceID computes the LPIOC_GET_DEVICEID ioctl request number
+// for a buffer of size bytes, matching <asm-generic/ioctl.h>'s _IOC(dir,
+// type, nr, size) macro: direction=READ, type='P', nr=1.
+func lpIOCGetDeviceID(size int) uintptr {
+	const (
+		iocRead      = 2
+		iocNrShift   = 0
+		iocTypeShift = 8
+		iocSizeShift = 16
+		iocDirShift  = 30
+	)
+	return uintptr(iocRead<<iocDirShift | size<<iocSizeShift | 'P'<<iocTypeShift | 1<<iocNrShift)
+}
+
+// Discover walks /dev/usb/lp* and reads each device's IEEE-1284 Device ID
+// via LPIOC_GET_DEVICEID.
+func Discover() ([]DeviceInfo, error) {
+	entries, err := filepath.Glob("/dev/usb/lp*")
+	if err != nil {
+		return nil, fmt.Errorf("discovery: globbing /dev/usb/lp*: %w", err)
+	}
+
+	var found []DeviceInfo
+	for _, path := range entries {
+		info, err := readDeviceID(path)
+		if err != nil {
+			continue
+		}
+		found = append(found, info)
+	}
+
+	if len(found) == 0 {
+		return nil, ErrNoDevicesFound
+	}
+
+	return found, nil
+}
+
+// readDeviceID opens path and issues LPIOC_GET_DEVICEID to read its
+// IEEE-1284 Device ID string.
+func readDeviceID(path string) (DeviceInfo, error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return DeviceInfo{}, err
+	}
+	defer f.Close()
+
+	// The Device ID is length-prefixed by two bytes (big-endian) giving the
+	// total size including the length field itself.
+	buf := make([]byte, 1024)
+	req := lpIOCGetDeviceID(len(buf))
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), req, uintptr(unsafe.Pointer(&buf[0])))
+	if errno != 0 {
+		return DeviceInfo{}, errno
+	}
+
+	length := int(buf[0])<<8 | int(buf[1])
+	if length < 2 || length > len(buf) {
+		return DeviceInfo{}, fmt.Errorf("discovery: %s returned an invalid Device ID length %d", path, length)
+	}
+
+	return parseDeviceID(path, string(buf[2:length])), nil
+}
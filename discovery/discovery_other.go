@@ -0,0 +1,9 @@
+//go:build !linux
+
+package discovery
+
+// Discover is only implemented on Linux, where USB printers expose their
+// IEEE-1284 Device ID through the lp driver's LPIOC_GET_DEVICEID ioctl.
+func Discover() ([]DeviceInfo, error) {
+	return nil, ErrNoDevicesFound
+}
@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync"
 	"time"
 
 	"golang.org/x/text/encoding/simplifiedchinese"
 	"golang.org/x/text/transform"
+
+	"github.com/yuebaibai/go-escpos/profiles"
 )
 
 var ErrorNoDevicesFound = errors.New("no devices found")
@@ -21,12 +24,29 @@ type characterConverter interface {
 type Printer struct {
 	s io.ReadWriteCloser
 	f *os.File
+
+	// printWidth is the current print area width in dots, used by Image to
+	// size its raster output. It defaults to defaultPrintWidth (the profile's
+	// MaxWidthDots under NewPrinterWithProfile) and is kept in sync by
+	// PrintAreaWidth.
+	printWidth int
+
+	// profile is the printer's capability profile, set by
+	// NewPrinterWithProfile. It is nil for printers constructed with
+	// NewPrinterByRW, in which case feature checks are skipped.
+	profile *profiles.Profile
+
+	// statusMu serializes the DLE EOT n request/response exchanges in
+	// status.go against p.s, since ESC/POS interleaves real-time status
+	// replies with ordinary print data on the same stream.
+	statusMu sync.Mutex
 }
 
 func NewPrinterByRW(rwc io.ReadWriteCloser) (*Printer, error) {
 
 	return &Printer{
-		s: rwc,
+		s:          rwc,
+		printWidth: defaultPrintWidth,
 	}, nil
 }
 
@@ -54,6 +74,9 @@ func (p *Printer) Close() error {
 
 // Cut sends the command to cut the paper
 func (p *Printer) Cut() error {
+	if p.profile != nil && !p.profile.HasCutter {
+		return ErrUnsupported{Feature: "auto-cutter", Profile: p.profile.Name}
+	}
 	return p.write("\x1DVA0")
 }
 
@@ -124,6 +147,8 @@ func (p *Printer) Align(align Alignment) error {
 
 // PrintAreaWidth will set the print area width, by default it is the maximum. Eg. 380 is handy for less wide receipts used by card terminals
 func (p *Printer) PrintAreaWidth(width int) error {
+	width = p.clampPrintAreaWidth(width)
+
 	var nh, nl uint8
 	if width < 256 {
 		nh = 0
@@ -132,11 +157,15 @@ func (p *Printer) PrintAreaWidth(width int) error {
 		nh = uint8(width / 256)
 		nl = uint8(width % 256)
 	}
+	p.printWidth = width
 	return p.write(fmt.Sprintf("\x1DW%c%c", nl, nh))
 }
 
 // Barcode will print a barcode of a specified type as well as the text value
 func (p *Printer) Barcode(barcode string, format BarcodeType) error {
+	if err := p.checkBarcode(barcodeTypeNames[format]); err != nil {
+		return err
+	}
 
 	// set width/height to default
 	err := p.write("\x1d\x77\x04\x1d\x68\x64")
@@ -178,9 +207,10 @@ func (p *Printer) Barcode(barcode string, format BarcodeType) error {
 	return p.PrintLn(barcode)
 }
 
-// QR will print a QR code with given data, the size is between 2 and 16, if an invalid size is given it will default to 3
+// QR will print a QR code with given data, the size is between 2 and 16, if an invalid size is given it will default to 3.
+// It uses error correction level L and model 2; use QRWithOptions for control over those.
 func (p *Printer) QR(code string, size int) error {
-	return p.twodimensionBarcode("\x31", code, size)
+	return p.QRWithOptions(code, QROptions{Size: size})
 }
 
 // PDF417 will print a PDF417 code with given data, the size is between 2 and 16, if an invalid size is given it will default to 3
@@ -199,6 +229,9 @@ func (p *Printer) DataMatrix(code string, size int) error {
 }
 
 func (p *Printer) twodimensionBarcode(codetype string, code string, size int) error {
+	if err := p.checkTwoDCode(twoDCodeNames[codetype]); err != nil {
+		return err
+	}
 	if size < 2 || size > 16 {
 		size = 3
 	}
@@ -220,7 +253,15 @@ func (p *Printer) twodimensionBarcode(codetype string, code string, size int) er
 	return nil
 }
 
+// GetErrorStatus reads the raw DLE EOT 2 error status byte.
+//
+// Deprecated: use ErrorStatus, which decodes the same byte into a typed
+// struct, or PrinterStatus/OfflineStatus/PaperStatus for the rest of the
+// DLE EOT n family.
 func (p *Printer) GetErrorStatus() (ErrorStatus, error) {
+	p.statusMu.Lock()
+	defer p.statusMu.Unlock()
+
 	_, err := p.s.Write([]byte{0x10, 0x04, 0x02})
 	if err != nil {
 		return 0, err
@@ -236,6 +277,9 @@ func (p *Printer) GetErrorStatus() (ErrorStatus, error) {
 
 // WriteBytes 写入字节切片
 func (p *Printer) WriteBytes(data []byte) error {
+	p.statusMu.Lock()
+	defer p.statusMu.Unlock()
+
 	if p.f != nil {
 		p.f.SetWriteDeadline(time.Now().Add(10 * time.Second))
 	}
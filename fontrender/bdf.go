@@ -0,0 +1,268 @@
+// Package fontrender rasterizes UTF-8 text using BDF bitmap fonts, giving
+// callers a way to print scripts (CJK, Cyrillic, emoji, ...) that a
+// printer's built-in ROM charsets don't cover. The resulting image.Image
+// can be fed to (*escpos.Printer).Image.
+package fontrender
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// glyph holds one character's bitmap and placement metrics, as read from a
+// BDF BITMAP block.
+type glyph struct {
+	width, height int
+	xOff, yOff    int
+	deviceWidth   int
+	bitmap        [][]bool // [row][col], true = ink
+}
+
+// Font is a parsed BDF bitmap font, ready to rasterize text.
+type Font struct {
+	ascent, descent int
+	glyphs          map[rune]*glyph
+}
+
+// LoadBDF parses a BDF (Glyph Bitmap Distribution Format) font from r.
+func LoadBDF(r io.Reader) (*Font, error) {
+	f := &Font{glyphs: make(map[rune]*glyph)}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var cur *glyph
+	var curRune rune
+	inBitmap := false
+	bitmapRow := 0
+
+	// pendingDWidth holds a DWIDTH value until BBX allocates the glyph it
+	// belongs to: BDF emits DWIDTH before BBX, so cur still points at the
+	// previous glyph (or is nil) when DWIDTH is parsed.
+	var pendingDWidth int
+	haveDWidth := false
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case inBitmap:
+			if fields[0] == "ENDCHAR" {
+				inBitmap = false
+				if cur != nil {
+					f.glyphs[curRune] = cur
+				}
+				continue
+			}
+			row, err := strconv.ParseUint(strings.TrimSpace(fields[0]), 16, 64)
+			if err != nil {
+				return nil, fmt.Errorf("fontrender: invalid BITMAP row %q: %w", fields[0], err)
+			}
+			bits := int(len(fields[0])) * 4
+			for x := 0; x < cur.width; x++ {
+				bit := bits - 1 - x
+				cur.bitmap[bitmapRow][x] = bit >= 0 && row&(1<<uint(bit)) != 0
+			}
+			bitmapRow++
+
+		case fields[0] == "FONT_ASCENT":
+			f.ascent, _ = strconv.Atoi(fields[1])
+		case fields[0] == "FONT_DESCENT":
+			f.descent, _ = strconv.Atoi(fields[1])
+
+		case fields[0] == "ENCODING":
+			n, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("fontrender: invalid ENCODING %q: %w", fields[1], err)
+			}
+			curRune = rune(n)
+
+		case fields[0] == "DWIDTH":
+			pendingDWidth, _ = strconv.Atoi(fields[1])
+			haveDWidth = true
+
+		case fields[0] == "BBX":
+			if len(fields) != 5 {
+				return nil, fmt.Errorf("fontrender: malformed BBX line %q", line)
+			}
+			w, _ := strconv.Atoi(fields[1])
+			h, _ := strconv.Atoi(fields[2])
+			xOff, _ := strconv.Atoi(fields[3])
+			yOff, _ := strconv.Atoi(fields[4])
+			cur = &glyph{width: w, height: h, xOff: xOff, yOff: yOff, deviceWidth: w}
+			cur.bitmap = make([][]bool, h)
+			for i := range cur.bitmap {
+				cur.bitmap[i] = make([]bool, w)
+			}
+			if haveDWidth {
+				cur.deviceWidth = pendingDWidth
+				haveDWidth = false
+			}
+
+		case fields[0] == "BITMAP":
+			inBitmap = true
+			bitmapRow = 0
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("fontrender: reading BDF: %w", err)
+	}
+
+	if len(f.glyphs) == 0 {
+		return nil, fmt.Errorf("fontrender: no glyphs found in BDF font")
+	}
+
+	return f, nil
+}
+
+// lineHeight is the vertical advance between baselines.
+func (f *Font) lineHeight() int {
+	return f.ascent + f.descent
+}
+
+// Render rasterizes text into a 1-bit image, soft-wrapping on spaces and
+// hard-wrapping at glyph boundaries once a line would exceed maxWidth. If
+// wrap is false, text is laid out on a single line regardless of maxWidth.
+func (f *Font) Render(text string, maxWidth int, wrap bool) image.Image {
+	lines := f.layout(text, maxWidth, wrap)
+
+	height := len(lines) * f.lineHeight()
+	if height == 0 {
+		height = f.lineHeight()
+	}
+	width := maxWidth
+	if width <= 0 {
+		for _, line := range lines {
+			if w := f.lineWidth(line); w > width {
+				width = w
+			}
+		}
+	}
+
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+
+	for row, line := range lines {
+		x := 0
+		baseY := row*f.lineHeight() + f.ascent
+		for _, r := range line {
+			g := f.glyphs[r]
+			if g == nil {
+				x += f.spaceWidth()
+				continue
+			}
+			f.drawGlyph(img, g, x, baseY)
+			x += g.deviceWidth
+		}
+	}
+
+	return img
+}
+
+func (f *Font) drawGlyph(img *image.Gray, g *glyph, x, baseY int) {
+	for row := 0; row < g.height; row++ {
+		for col := 0; col < g.width; col++ {
+			if !g.bitmap[row][col] {
+				continue
+			}
+			px := x + g.xOff + col
+			py := baseY - g.yOff - (g.height - 1 - row)
+			if px < 0 || py < 0 || px >= img.Bounds().Dx() || py >= img.Bounds().Dy() {
+				continue
+			}
+			img.SetGray(px, py, color.Gray{Y: 0})
+		}
+	}
+}
+
+// spaceWidth is used as the advance for runes missing from the font.
+func (f *Font) spaceWidth() int {
+	if g := f.glyphs[' ']; g != nil {
+		return g.deviceWidth
+	}
+	return f.lineHeight() / 2
+}
+
+func (f *Font) lineWidth(line []rune) int {
+	w := 0
+	for _, r := range line {
+		if g := f.glyphs[r]; g != nil {
+			w += g.deviceWidth
+		} else {
+			w += f.spaceWidth()
+		}
+	}
+	return w
+}
+
+// layout splits text into lines of runes, soft-wrapping on spaces and
+// falling back to a hard break mid-word when a single word is wider than
+// maxWidth.
+func (f *Font) layout(text string, maxWidth int, wrap bool) [][]rune {
+	if !wrap || maxWidth <= 0 {
+		return [][]rune{[]rune(text)}
+	}
+
+	var lines [][]rune
+	var line []rune
+	lineWidth := 0
+
+	flush := func() {
+		lines = append(lines, line)
+		line = nil
+		lineWidth = 0
+	}
+
+	for _, word := range strings.Split(text, " ") {
+		runes := []rune(word)
+		wordWidth := f.lineWidth(runes)
+
+		if lineWidth > 0 && lineWidth+f.spaceWidth()+wordWidth > maxWidth {
+			flush()
+		} else if lineWidth > 0 {
+			line = append(line, ' ')
+			lineWidth += f.spaceWidth()
+		}
+
+		for wordWidth > maxWidth {
+			// Hard-wrap a single overlong word at a glyph boundary.
+			cut := 0
+			w := 0
+			for _, r := range runes {
+				gw := f.spaceWidth()
+				if g := f.glyphs[r]; g != nil {
+					gw = g.deviceWidth
+				}
+				if w+gw > maxWidth {
+					break
+				}
+				w += gw
+				cut++
+			}
+			if cut == 0 {
+				cut = 1
+			}
+			line = append(line, runes[:cut]...)
+			flush()
+			runes = runes[cut:]
+			wordWidth = f.lineWidth(runes)
+		}
+
+		line = append(line, runes...)
+		lineWidth += wordWidth
+	}
+	flush()
+
+	return lines
+}
@@ -0,0 +1,282 @@
+package escpos
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+)
+
+// Density selects the raster density used when printing an Image.
+type Density uint8
+
+const (
+	// DensitySingle prints via GS v 0 at the printer's native ~90dpi density.
+	DensitySingle Density = 0
+	// DensityDouble prints via the ESC * column bit-image command's 8-dot
+	// double-density mode, roughly 180dpi horizontally.
+	DensityDouble Density = 1
+)
+
+// Dither selects the algorithm used to reduce a grayscale image to 1-bpp.
+type Dither uint8
+
+const (
+	// DitherNone thresholds each pixel against ImageOptions.Threshold.
+	DitherNone Dither = iota
+	// DitherFloydSteinberg diffuses quantization error to neighbouring pixels.
+	DitherFloydSteinberg
+	// DitherBayer4x4 applies a 4x4 ordered (Bayer) dither matrix.
+	DitherBayer4x4
+)
+
+// ImageOptions controls how Image rasterizes and prints a picture.
+type ImageOptions struct {
+	// Density selects single (~90dpi) or double (~180dpi) raster density.
+	Density Density
+	// Threshold is the grayscale cut-off (0-255) used when Dither is
+	// DitherNone. It is a pointer so that an explicit 0 (print only pure
+	// black) can be told apart from leaving it unset, which defaults to 128.
+	Threshold *uint8
+	// Dither selects the dithering algorithm applied before printing.
+	Dither Dither
+}
+
+// maxBandHeight is the tallest slice sent in a single GS v 0 command, since
+// some firmwares choke on raster images taller than a single print buffer.
+const maxBandHeight = 255
+
+// defaultPrintWidth is the print-area width, in dots, assumed until
+// PrintAreaWidth has been called. It matches a typical 58mm thermal head.
+const defaultPrintWidth = 384
+
+var bayer4x4 = [4][4]int{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+// Image renders img to a 1-bpp bitmap sized to the current print area and
+// prints it. DensitySingle uses the GS v 0 raster bit-image command, sent
+// at normal scale. DensityDouble instead falls back to the ESC * column
+// bit-image command in its 8-dot double-density mode, for older printers
+// whose GS v 0 implementation can't address the extra horizontal dots.
+func (p *Printer) Image(img image.Image, opts ImageOptions) error {
+	if err := p.checkImage(); err != nil {
+		return err
+	}
+
+	bitmap, widthBytes, height := p.rasterize(img, opts)
+
+	if opts.Density == DensityDouble {
+		return p.printColumnMode(bitmap, widthBytes, height)
+	}
+
+	for y := 0; y < height; y += maxBandHeight {
+		bandHeight := height - y
+		if bandHeight > maxBandHeight {
+			bandHeight = maxBandHeight
+		}
+
+		band := bitmap[y*widthBytes : (y+bandHeight)*widthBytes]
+		header := "\x1dv0" + rawBytes(0, byte(widthBytes&0xff), byte((widthBytes>>8)&0xff), byte(bandHeight&0xff), byte((bandHeight>>8)&0xff))
+		if err := p.write(header + string(band)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// printColumnMode prints a packed 1-bpp bitmap with the ESC * column
+// bit-image command in 8-dot double-density mode (m=1), one 8-row band at
+// a time, advancing the paper by 8 dots between bands.
+func (p *Printer) printColumnMode(bitmap []byte, widthBytes, height int) error {
+	width := widthBytes * 8
+
+	for y := 0; y < height; y += 8 {
+		bandHeight := height - y
+		if bandHeight > 8 {
+			bandHeight = 8
+		}
+
+		header := "\x1b*" + rawBytes(1, byte(width&0xff), byte((width>>8)&0xff))
+
+		columns := make([]byte, width)
+		for x := 0; x < width; x++ {
+			var col byte
+			for row := 0; row < bandHeight; row++ {
+				if bitmap[(y+row)*widthBytes+x/8]&(0x80>>uint(x%8)) != 0 {
+					col |= 0x80 >> uint(row)
+				}
+			}
+			columns[x] = col
+		}
+
+		if err := p.write(header + string(columns)); err != nil {
+			return err
+		}
+		if err := p.write("\x1bJ\x08"); err != nil { // feed 8 dots to the next band
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rasterize resizes img to the current print area width, converts it to
+// grayscale, applies opts.Dither and packs the result 8 pixels per byte,
+// MSB-first. It returns the packed bitmap along with its row stride in
+// bytes and its height in pixels.
+func (p *Printer) rasterize(img image.Image, opts ImageOptions) (bitmap []byte, widthBytes, height int) {
+	width := p.printWidth
+	if width <= 0 {
+		width = defaultPrintWidth
+	}
+
+	gray := toGrayscale(resizeToWidth(img, width))
+	bounds := gray.Bounds()
+	width, height = bounds.Dx(), bounds.Dy()
+
+	threshold := uint8(128)
+	if opts.Threshold != nil {
+		threshold = *opts.Threshold
+	}
+
+	bits := make([][]bool, height)
+	for y := 0; y < height; y++ {
+		bits[y] = make([]bool, width)
+	}
+
+	switch opts.Dither {
+	case DitherFloydSteinberg:
+		ditherFloydSteinberg(gray, bits, threshold)
+	case DitherBayer4x4:
+		ditherBayer4x4(gray, bits, threshold)
+	default:
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				bits[y][x] = gray.GrayAt(x, y).Y < threshold
+			}
+		}
+	}
+
+	widthBytes = (width + 7) / 8
+	bitmap = make([]byte, widthBytes*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if bits[y][x] {
+				bitmap[y*widthBytes+x/8] |= 0x80 >> uint(x%8)
+			}
+		}
+	}
+
+	return bitmap, widthBytes, height
+}
+
+func toGrayscale(img image.Image) *image.Gray {
+	bounds := img.Bounds()
+	gray := image.NewGray(bounds)
+	draw.Draw(gray, bounds, img, bounds.Min, draw.Src)
+	return gray
+}
+
+// resizeToWidth scales img down to width using nearest-neighbour sampling,
+// preserving aspect ratio. Images already narrower than width are returned
+// unchanged.
+func resizeToWidth(img image.Image, width int) image.Image {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	if srcW <= width {
+		return img
+	}
+
+	height := srcH * width / srcW
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		srcY := bounds.Min.Y + y*srcH/height
+		for x := 0; x < width; x++ {
+			srcX := bounds.Min.X + x*srcW/width
+			dst.Set(x, y, img.At(srcX, srcY))
+		}
+	}
+	return dst
+}
+
+// ditherFloydSteinberg performs classic error-diffusion dithering: the
+// quantization error of each pixel is pushed onto its unvisited neighbours.
+func ditherFloydSteinberg(gray *image.Gray, bits [][]bool, threshold uint8) {
+	bounds := gray.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	errs := make([][]int, height)
+	for y := range errs {
+		errs[y] = make([]int, width)
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			level := int(gray.GrayAt(bounds.Min.X+x, bounds.Min.Y+y).Y) + errs[y][x]
+			black := level < int(threshold)
+			bits[y][x] = black
+
+			var actual int
+			if black {
+				actual = 0
+			} else {
+				actual = 255
+			}
+			quantErr := level - actual
+
+			if x+1 < width {
+				errs[y][x+1] += quantErr * 7 / 16
+			}
+			if y+1 < height {
+				if x > 0 {
+					errs[y+1][x-1] += quantErr * 3 / 16
+				}
+				errs[y+1][x] += quantErr * 5 / 16
+				if x+1 < width {
+					errs[y+1][x+1] += quantErr * 1 / 16
+				}
+			}
+		}
+	}
+}
+
+// ditherBayer4x4 applies an ordered dither using the standard 4x4 Bayer
+// matrix, cheaper than Floyd-Steinberg and free of directional artifacts.
+func ditherBayer4x4(gray *image.Gray, bits [][]bool, threshold uint8) {
+	bounds := gray.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			level := int(gray.GrayAt(bounds.Min.X+x, bounds.Min.Y+y).Y)
+			bayerLevel := (bayer4x4[y%4][x%4]+1)*256/17 - 128
+			bits[y][x] = level+bayerLevel < int(threshold)
+		}
+	}
+}
+
+// DefineNVImages uploads images into the printer's non-volatile memory with
+// FS q, so they can later be reprinted with PrintNVImage without resending
+// the raster data over the wire.
+func (p *Printer) DefineNVImages(images []image.Image) error {
+	cmd := "\x1cq" + rawBytes(byte(len(images)))
+	for _, img := range images {
+		bitmap, widthBytes, height := p.rasterize(img, ImageOptions{})
+		cmd += rawBytes(byte(widthBytes&0xff), byte((widthBytes>>8)&0xff), byte(height&0xff), byte((height>>8)&0xff))
+		cmd += string(bitmap)
+	}
+	return p.write(cmd)
+}
+
+// PrintNVImage prints a previously defined NV image by its 1-based id.
+func (p *Printer) PrintNVImage(id uint8) error {
+	return p.write(fmt.Sprintf("\x1cp%c%c", id, 0))
+}
@@ -0,0 +1,148 @@
+package escpos
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Job is a chainable, buffered print job. Every command is appended to an
+// internal buffer and only reaches the printer when Commit is called, so a
+// network failure partway through building the job can't leave the printer
+// in a half-printed state. Errors raised while building the job are
+// captured and returned by Commit or Err, rather than aborting the chain.
+type Job struct {
+	p    *Printer
+	buf  bytes.Buffer
+	err  error
+	sent bool
+}
+
+// NewJob returns an empty Job bound to p.
+func (p *Printer) NewJob() *Job {
+	return &Job{p: p}
+}
+
+// Err returns the first error encountered while building the job, if any.
+func (j *Job) Err() error {
+	return j.err
+}
+
+func (j *Job) write(cmd string) *Job {
+	if j.err != nil {
+		return j
+	}
+	j.buf.WriteString(cmd)
+	return j
+}
+
+// Print buffers text, encoded the same way Printer.Print encodes it.
+func (j *Job) Print(data string) *Job {
+	if j.err != nil || data == "" {
+		return j
+	}
+	b, _, err := converter.Encode([]byte(data))
+	if err != nil {
+		j.err = err
+		return j
+	}
+	return j.write(textReplace(string(b)))
+}
+
+// PrintLn buffers text followed by a newline.
+func (j *Job) PrintLn(data string) *Job {
+	return j.Print(data).write("\n")
+}
+
+// LineFeed buffers n paper feeds.
+func (j *Job) LineFeed(n int) *Job {
+	return j.write(fmt.Sprintf("\x1Bd%c", n))
+}
+
+// Bold buffers a bold on/off toggle.
+func (j *Job) Bold(enabled bool) *Job {
+	if enabled {
+		return j.write(fmt.Sprintf("\x1BE%c", 1))
+	}
+	return j.write(fmt.Sprintf("\x1BE%c", 0))
+}
+
+// Underline buffers an underline on/off toggle.
+func (j *Job) Underline(enabled bool) *Job {
+	if enabled {
+		return j.write(fmt.Sprintf("\x1B-%c", 1))
+	}
+	return j.write(fmt.Sprintf("\x1B-%c", 0))
+}
+
+// Size buffers a font size change.
+func (j *Job) Size(width, height uint8) *Job {
+	return j.write(fmt.Sprintf("\x1D!%c", ((width-1)<<4)|(height-1)))
+}
+
+// Font buffers a font face change.
+func (j *Job) Font(font Font) *Job {
+	return j.write(fmt.Sprintf("\x1BM%c", font))
+}
+
+// Align buffers a text alignment change.
+func (j *Job) Align(align Alignment) *Job {
+	return j.write(fmt.Sprintf("\x1Ba%c", align))
+}
+
+// QR buffers a QR code with error correction level L. Unlike Printer.QR it
+// sends no model-select command, leaving the printer's current QR model in
+// effect, and it does not consult the printer's profile.
+func (j *Job) QR(code string, size int) *Job {
+	if size < 2 || size > 16 {
+		size = 3
+	}
+	const twoDbar = "\x1d\x28\x6b"
+
+	j.write(twoDbar + "\x03\x00\x31\x43" + fmt.Sprintf("%c", size))
+	j.write(twoDbar + "\x03\x00\x31\x45\x30\x0A")
+
+	codePL := len(code) + 3
+	codePH := codePL / 256
+	codePL = codePL % 256
+	j.write(twoDbar + rawBytes(byte(codePL), byte(codePH)) + "\x31\x50\x30" + code)
+
+	return j.write(twoDbar + "\x03\x00\x31\x51\x30\x0A")
+}
+
+// Cut buffers a paper cut.
+func (j *Job) Cut() *Job {
+	return j.write("\x1DVA0")
+}
+
+// Commit flushes the buffered commands to the printer in a single write
+// and clears the buffer. If an error was captured while building the job,
+// Commit returns it without writing anything.
+func (j *Job) Commit() error {
+	if j.err != nil {
+		return j.err
+	}
+	if j.buf.Len() == 0 {
+		return nil
+	}
+
+	if err := j.p.write(j.buf.String()); err != nil {
+		j.err = err
+		return err
+	}
+	j.buf.Reset()
+	j.sent = true
+	return nil
+}
+
+// Rollback discards any buffered, uncommitted commands. If an earlier
+// Commit already sent bytes to the printer, it also sends ESC @ to reset
+// the printer to a known state, since those bytes can't be un-sent.
+func (j *Job) Rollback() error {
+	j.buf.Reset()
+	j.err = nil
+	if j.sent {
+		j.sent = false
+		return j.p.write("\x1B@")
+	}
+	return nil
+}
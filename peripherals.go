@@ -0,0 +1,70 @@
+package escpos
+
+import (
+	"fmt"
+	"time"
+)
+
+// CashDrawerPin selects which of the two drawer kick-out connectors to pulse.
+type CashDrawerPin uint8
+
+const (
+	// CashDrawerPin2 pulses pin 2 of the drawer connector.
+	CashDrawerPin2 CashDrawerPin = 0
+	// CashDrawerPin5 pulses pin 5 of the drawer connector.
+	CashDrawerPin5 CashDrawerPin = 1
+)
+
+// CashDrawer kicks the cash drawer connected to pin, holding the pulse low
+// for onMs then high for offMs, in 2ms units per the ESC/POS spec.
+func (p *Printer) CashDrawer(pin CashDrawerPin, onMs, offMs uint8) error {
+	if p.profile != nil && !p.profile.HasCashDrawer {
+		return ErrUnsupported{Feature: "cash drawer", Profile: p.profile.Name}
+	}
+	return p.write(fmt.Sprintf("\x1bp%c%c%c", pin, onMs, offMs))
+}
+
+// Buzzer sounds the printer's internal buzzer times times, each for
+// duration (rounded down to the nearest 100ms, the unit ESC B expects).
+func (p *Printer) Buzzer(times uint8, duration time.Duration) error {
+	if p.profile != nil && !p.profile.HasBuzzer {
+		return ErrUnsupported{Feature: "buzzer", Profile: p.profile.Name}
+	}
+	t := uint8(duration / (100 * time.Millisecond))
+	return p.write(fmt.Sprintf("\x1bB%c%c", times, t))
+}
+
+// LineDisplay drives an ESC/POS customer line display (a pole display
+// chained off the printer's serial/USB connection).
+type LineDisplay struct {
+	p *Printer
+}
+
+// LineDisplay returns a handle for controlling the customer line display.
+func (p *Printer) LineDisplay() *LineDisplay {
+	return &LineDisplay{p: p}
+}
+
+// Open switches the display into ESC/POS customer display mode.
+func (d *LineDisplay) Open() error {
+	return d.p.write("\x1b@")
+}
+
+// Clear erases the display and homes the cursor.
+func (d *LineDisplay) Clear() error {
+	return d.p.write("\x0c")
+}
+
+// WriteLine moves the cursor to row (0 or 1 on a standard two-line display)
+// and writes text starting at its leftmost column.
+func (d *LineDisplay) WriteLine(row int, text string) error {
+	if err := d.p.write(fmt.Sprintf("\x1f\x24\x01%c", row+1)); err != nil {
+		return err
+	}
+	return d.p.write(text)
+}
+
+// Close returns the display to its idle, clock-showing state.
+func (d *LineDisplay) Close() error {
+	return d.p.write("\x1f\x11\x01")
+}
@@ -0,0 +1,109 @@
+package escpos
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/yuebaibai/go-escpos/profiles"
+)
+
+// ErrUnsupported is returned by methods that consult a printer's profile
+// (see NewPrinterWithProfile) when the underlying model doesn't support the
+// requested feature, instead of writing bytes the printer would misread.
+type ErrUnsupported struct {
+	Feature string
+	Profile string
+}
+
+func (e ErrUnsupported) Error() string {
+	return fmt.Sprintf("escpos: %s is not supported by printer profile %q", e.Feature, e.Profile)
+}
+
+// NewPrinterWithProfile returns a Printer that consults profileName's
+// capability profile, rejecting unsupported features with ErrUnsupported
+// instead of sending bytes the printer won't understand.
+func NewPrinterWithProfile(rwc io.ReadWriteCloser, profileName string) (*Printer, error) {
+	profile, ok := profiles.Get(profileName)
+	if !ok {
+		return nil, profiles.ErrUnknownProfile{Name: profileName}
+	}
+
+	p, err := NewPrinterByRW(rwc)
+	if err != nil {
+		return nil, err
+	}
+	p.profile = &profile
+	p.printWidth = profile.MaxWidthDots
+	return p, nil
+}
+
+// checkBarcode returns ErrUnsupported if the printer's profile doesn't list
+// name among its supported barcode symbologies. It is a no-op when no
+// profile was configured.
+func (p *Printer) checkBarcode(name string) error {
+	if p.profile == nil {
+		return nil
+	}
+	if !p.profile.Supports(p.profile.Barcodes, name) {
+		return ErrUnsupported{Feature: "barcode type " + name, Profile: p.profile.Name}
+	}
+	return nil
+}
+
+// checkTwoDCode returns ErrUnsupported if the printer's profile doesn't
+// list name among its supported 2D symbologies.
+func (p *Printer) checkTwoDCode(name string) error {
+	if p.profile == nil {
+		return nil
+	}
+	if !p.profile.Supports(p.profile.TwoDCodes, name) {
+		return ErrUnsupported{Feature: "2D code type " + name, Profile: p.profile.Name}
+	}
+	return nil
+}
+
+// checkImage returns ErrUnsupported if the printer's profile declares the
+// raster image command unavailable.
+func (p *Printer) checkImage() error {
+	if p.profile == nil {
+		return nil
+	}
+	if !p.profile.SupportsImage {
+		return ErrUnsupported{Feature: "raster images", Profile: p.profile.Name}
+	}
+	return nil
+}
+
+// barcodeTypeNames maps each BarcodeType to the profile-facing name used in
+// Profile.Barcodes.
+var barcodeTypeNames = map[BarcodeType]string{
+	BarcodeTypeUPCA:    "UPCA",
+	BarcodeTypeUPCE:    "UPCE",
+	BarcodeTypeEAN13:   "EAN13",
+	BarcodeTypeEAN8:    "EAN8",
+	BarcodeTypeCODE39:  "CODE39",
+	BarcodeTypeITF:     "ITF",
+	BarcodeTypeCODABAR: "CODABAR",
+	BarcodeTypeCODE128: "CODE128",
+}
+
+// twoDCodeNames maps each twodimensionBarcode command byte to the
+// profile-facing name used in Profile.TwoDCodes.
+var twoDCodeNames = map[string]string{
+	"\x31": "QR",
+	"\x30": "PDF417",
+	"\x35": "Aztec",
+	"\x36": "DataMatrix",
+}
+
+// clampPrintAreaWidth returns width clamped to the profile's max dot width,
+// or width unchanged when no profile is configured.
+func (p *Printer) clampPrintAreaWidth(width int) int {
+	if p.profile == nil || p.profile.MaxWidthDots <= 0 {
+		return width
+	}
+	if width > p.profile.MaxWidthDots {
+		return p.profile.MaxWidthDots
+	}
+	return width
+}
@@ -0,0 +1,111 @@
+// Package profiles describes what individual ESC/POS printer models
+// support, so callers can be told up front that a feature is unavailable
+// instead of having the printer silently misinterpret unsupported bytes.
+// It follows the capability-profile approach used by python-escpos.
+package profiles
+
+import "fmt"
+
+// Profile describes the feature set of a printer model.
+type Profile struct {
+	// Name is the model identifier this profile was registered under.
+	Name string
+
+	// MaxWidthDots is the print head width in dots.
+	MaxWidthDots int
+	// ColumnsFontA and ColumnsFontB are the character columns available in
+	// each built-in font at the default point size.
+	ColumnsFontA int
+	ColumnsFontB int
+
+	// Barcodes lists the supported BarcodeType names, e.g. "CODE39".
+	Barcodes []string
+	// TwoDCodes lists the supported 2D symbologies, e.g. "QR", "PDF417".
+	TwoDCodes []string
+	// CodePages lists the supported character encodings, e.g. "ISO8859-15".
+	CodePages []string
+
+	// SupportsImage reports whether the raster GS v 0 image command works.
+	SupportsImage bool
+	// HasCutter reports whether the printer has an auto-cutter.
+	HasCutter bool
+	// HasCashDrawer reports whether the cash-drawer kick-out connector is present.
+	HasCashDrawer bool
+	// HasBuzzer reports whether the printer has an internal buzzer.
+	HasBuzzer bool
+}
+
+// Supports reports whether feature is present in the given list, e.g.
+// p.Supports(p.Barcodes, "CODE39").
+func (p Profile) Supports(list []string, feature string) bool {
+	for _, f := range list {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+var registry = map[string]Profile{
+	"TM-T20": {
+		Name:          "TM-T20",
+		MaxWidthDots:  384,
+		ColumnsFontA:  48,
+		ColumnsFontB:  64,
+		Barcodes:      []string{"UPCA", "UPCE", "EAN13", "EAN8", "CODE39", "ITF", "CODABAR", "CODE128"},
+		TwoDCodes:     []string{"QR"},
+		CodePages:     []string{"ISO8859-15", "GBK"},
+		SupportsImage: true,
+		HasCutter:     true,
+		HasCashDrawer: true,
+		HasBuzzer:     false,
+	},
+	"TM-T88V": {
+		Name:          "TM-T88V",
+		MaxWidthDots:  576,
+		ColumnsFontA:  48,
+		ColumnsFontB:  64,
+		Barcodes:      []string{"UPCA", "UPCE", "EAN13", "EAN8", "CODE39", "ITF", "CODABAR", "CODE128"},
+		TwoDCodes:     []string{"QR", "PDF417", "Aztec", "DataMatrix"},
+		CodePages:     []string{"ISO8859-15", "GBK"},
+		SupportsImage: true,
+		HasCutter:     true,
+		HasCashDrawer: true,
+		HasBuzzer:     true,
+	},
+	"POS-80": {
+		Name:          "POS-80",
+		MaxWidthDots:  576,
+		ColumnsFontA:  42,
+		ColumnsFontB:  56,
+		Barcodes:      []string{"UPCA", "UPCE", "EAN13", "EAN8", "CODE39", "ITF", "CODABAR", "CODE128"},
+		TwoDCodes:     []string{"QR"},
+		CodePages:     []string{"ISO8859-15", "GBK"},
+		SupportsImage: true,
+		HasCutter:     true,
+		HasCashDrawer: true,
+		HasBuzzer:     true,
+	},
+}
+
+// Get returns the registered profile for name.
+func Get(name string) (Profile, bool) {
+	p, ok := registry[name]
+	return p, ok
+}
+
+// Register adds or overwrites a profile under name, letting callers
+// describe models that aren't in the built-in registry.
+func Register(name string, p Profile) {
+	p.Name = name
+	registry[name] = p
+}
+
+// ErrUnknownProfile is returned when a profile name has not been registered.
+type ErrUnknownProfile struct {
+	Name string
+}
+
+func (e ErrUnknownProfile) Error() string {
+	return fmt.Sprintf("profiles: unknown printer profile %q", e.Name)
+}
@@ -0,0 +1,200 @@
+package escpos
+
+import (
+	"context"
+	"time"
+)
+
+// PrinterStatusInfo decodes the DLE EOT 1 "printer status" response.
+type PrinterStatusInfo struct {
+	DrawerOpen bool
+	Offline    bool
+	FeedButton bool
+}
+
+// OfflineStatusInfo decodes the DLE EOT 3 "off-line status" response.
+type OfflineStatusInfo struct {
+	CoverOpen     bool
+	FeedByButton  bool
+	PaperEnd      bool
+	ErrorOccurred bool
+}
+
+// ErrorStatusDetail decodes the DLE EOT 2 "error status" response into its
+// constituent conditions, mirroring the byte GetErrorStatus returns raw.
+type ErrorStatusDetail struct {
+	AutoCutterError      bool
+	UnrecoverableError   bool
+	AutoRecoverableError bool
+}
+
+// PaperStatusInfo decodes the DLE EOT 4 "paper sensor status" response.
+type PaperStatusInfo struct {
+	NearEnd bool
+	Out     bool
+}
+
+// realtimeStatus sends a DLE EOT n real-time status transmission request
+// and reads back its single-byte reply. It holds statusMu for the
+// duration, since the DLE EOT n reply can otherwise be read out of order
+// with data the caller is concurrently printing.
+func (p *Printer) realtimeStatus(n byte) (byte, error) {
+	p.statusMu.Lock()
+	defer p.statusMu.Unlock()
+
+	if _, err := p.s.Write([]byte{0x10, 0x04, n}); err != nil {
+		return 0, err
+	}
+	buf := make([]byte, 1)
+	if _, err := p.s.Read(buf); err != nil {
+		return 0, err
+	}
+	return buf[0], nil
+}
+
+// PrinterStatus requests the DLE EOT 1 printer status.
+func (p *Printer) PrinterStatus() (PrinterStatusInfo, error) {
+	b, err := p.realtimeStatus(1)
+	if err != nil {
+		return PrinterStatusInfo{}, err
+	}
+	return PrinterStatusInfo{
+		DrawerOpen: b&0x04 == 0,
+		Offline:    b&0x08 != 0,
+		FeedButton: b&0x20 != 0,
+	}, nil
+}
+
+// ErrorStatus requests the DLE EOT 2 error status, replacing the raw byte
+// returned by the deprecated GetErrorStatus with decoded booleans.
+func (p *Printer) ErrorStatus() (ErrorStatusDetail, error) {
+	b, err := p.realtimeStatus(2)
+	if err != nil {
+		return ErrorStatusDetail{}, err
+	}
+	return ErrorStatusDetail{
+		AutoCutterError:      b&0x08 != 0,
+		UnrecoverableError:   b&0x20 != 0,
+		AutoRecoverableError: b&0x40 != 0,
+	}, nil
+}
+
+// OfflineStatus requests the DLE EOT 3 off-line status.
+func (p *Printer) OfflineStatus() (OfflineStatusInfo, error) {
+	b, err := p.realtimeStatus(3)
+	if err != nil {
+		return OfflineStatusInfo{}, err
+	}
+	return OfflineStatusInfo{
+		CoverOpen:     b&0x04 != 0,
+		FeedByButton:  b&0x08 != 0,
+		PaperEnd:      b&0x20 != 0,
+		ErrorOccurred: b&0x40 != 0,
+	}, nil
+}
+
+// PaperStatus requests the DLE EOT 4 paper sensor status.
+func (p *Printer) PaperStatus() (PaperStatusInfo, error) {
+	b, err := p.realtimeStatus(4)
+	if err != nil {
+		return PaperStatusInfo{}, err
+	}
+	return PaperStatusInfo{
+		NearEnd: b&0x0c != 0,
+		Out:     b&0x60 != 0,
+	}, nil
+}
+
+// StatusEvent describes a change observed between two polls of Watch.
+type StatusEvent struct {
+	Time    time.Time
+	Kind    string
+	Message string
+}
+
+// Watch polls the printer's paper, printer and off-line status at interval and emits
+// a StatusEvent each time a condition changes, e.g. paper running out or
+// the cover being closed again. The channel is closed when ctx is done.
+func (p *Printer) Watch(ctx context.Context, interval time.Duration) <-chan StatusEvent {
+	events := make(chan StatusEvent)
+
+	go func() {
+		defer close(events)
+
+		var lastPaper PaperStatusInfo
+		var lastPrinter PrinterStatusInfo
+		var lastOffline OfflineStatusInfo
+		haveBaseline := false
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				paper, paperErr := p.PaperStatus()
+				printer, printerErr := p.PrinterStatus()
+				offline, offlineErr := p.OfflineStatus()
+
+				if haveBaseline {
+					if paperErr == nil {
+						diffPaperStatus(ctx, events, lastPaper, paper)
+					}
+					if printerErr == nil {
+						diffPrinterStatus(ctx, events, lastPrinter, printer)
+					}
+					if offlineErr == nil && lastOffline.CoverOpen != offline.CoverOpen {
+						send(ctx, events, "cover", boolTransition(lastOffline.CoverOpen, offline.CoverOpen, "cover open", "cover closed"))
+					}
+				}
+
+				if paperErr == nil {
+					lastPaper = paper
+				}
+				if printerErr == nil {
+					lastPrinter = printer
+				}
+				if offlineErr == nil {
+					lastOffline = offline
+				}
+				haveBaseline = true
+			}
+		}
+	}()
+
+	return events
+}
+
+func diffPaperStatus(ctx context.Context, events chan<- StatusEvent, before, after PaperStatusInfo) {
+	if before.Out != after.Out {
+		send(ctx, events, "paper", boolTransition(before.Out, after.Out, "paper out", "paper loaded"))
+	}
+	if before.NearEnd != after.NearEnd {
+		send(ctx, events, "paper", boolTransition(before.NearEnd, after.NearEnd, "paper near end", "paper level ok"))
+	}
+}
+
+func diffPrinterStatus(ctx context.Context, events chan<- StatusEvent, before, after PrinterStatusInfo) {
+	if before.DrawerOpen != after.DrawerOpen {
+		send(ctx, events, "drawer", boolTransition(before.DrawerOpen, after.DrawerOpen, "drawer open", "drawer closed"))
+	}
+	if before.Offline != after.Offline {
+		send(ctx, events, "offline", boolTransition(before.Offline, after.Offline, "printer offline", "printer online"))
+	}
+}
+
+func boolTransition(before, after bool, whenTrue, whenFalse string) string {
+	if after {
+		return whenTrue
+	}
+	return whenFalse
+}
+
+func send(ctx context.Context, events chan<- StatusEvent, kind, message string) {
+	select {
+	case events <- StatusEvent{Time: time.Now(), Kind: kind, Message: message}:
+	case <-ctx.Done():
+	}
+}
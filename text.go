@@ -0,0 +1,16 @@
+package escpos
+
+import "github.com/yuebaibai/go-escpos/fontrender"
+
+// PrintText rasterizes text with f, wrapping it to the current print area
+// width, and prints it via Image. It is the escape hatch for scripts the
+// printer's ROM charsets don't cover (see fontrender.LoadBDF).
+func (p *Printer) PrintText(f *fontrender.Font, text string) error {
+	width := p.printWidth
+	if width <= 0 {
+		width = defaultPrintWidth
+	}
+
+	img := f.Render(text, width, true)
+	return p.Image(img, ImageOptions{})
+}
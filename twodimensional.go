@@ -0,0 +1,200 @@
+package escpos
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/datamatrix"
+	"github.com/boombuler/barcode/pdf417"
+	"github.com/boombuler/barcode/qr"
+)
+
+// QRErrorCorrection selects how much of a QR code's data can be
+// reconstructed if part of the print is damaged.
+type QRErrorCorrection byte
+
+const (
+	QRErrorCorrectionL QRErrorCorrection = iota // recovers ~7%
+	QRErrorCorrectionM                          // recovers ~15%
+	QRErrorCorrectionQ                          // recovers ~25%
+	QRErrorCorrectionH                          // recovers ~30%
+)
+
+// QROptions configures QRWithOptions and RenderQR. The zero value selects
+// size 3, model 2, error correction level L and automatic masking, the
+// same defaults QR used before options existed.
+type QROptions struct {
+	// Size is the module size, between 2 and 16.
+	Size int
+	// ECLevel is the error correction level.
+	ECLevel QRErrorCorrection
+	// Model selects the QR model: 1 (original) or 2 (current standard).
+	// Only QRWithOptions uses it; RenderQR ignores Model.
+	Model int
+	// Mask would force a specific mask pattern (0-7), but ESC/POS's GS ( k
+	// QR commands don't expose mask selection, so this is currently
+	// accepted and ignored; the printer always chooses its own mask.
+	Mask int
+}
+
+// QRWithOptions prints a QR code with an explicit error correction level
+// and model, where QR always used error correction level L.
+func (p *Printer) QRWithOptions(data string, opts QROptions) error {
+	if err := p.checkTwoDCode("QR"); err != nil {
+		return err
+	}
+
+	size := opts.Size
+	if size < 2 || size > 16 {
+		size = 3
+	}
+	model := opts.Model
+	if model != 1 {
+		model = 2
+	}
+
+	const twoDbar = "\x1d\x28\x6b"
+
+	// select the model
+	if err := p.write(twoDbar + fmt.Sprintf("\x04\x00\x31\x41%c\x00", 0x30+byte(model))); err != nil {
+		return err
+	}
+	// set module size
+	if err := p.write(twoDbar + fmt.Sprintf("\x03\x00\x31\x43%c", size)); err != nil {
+		return err
+	}
+	// set error correction level
+	if err := p.write(twoDbar + fmt.Sprintf("\x03\x00\x31\x45%c", 0x30+byte(opts.ECLevel))); err != nil {
+		return err
+	}
+
+	codePL := len(data) + 3
+	codePH := codePL / 256
+	codePL = codePL % 256
+	if err := p.write(twoDbar + rawBytes(byte(codePL), byte(codePH)) + "\x31\x50\x30" + data); err != nil {
+		return err
+	}
+
+	return p.write(twoDbar + "\x03\x00\x31\x51\x30\x0A")
+}
+
+// RenderQR rasterizes a QR code offline, without printing it, using
+// github.com/boombuler/barcode. This is useful when a printer's built-in
+// QR firmware is unreliable, or the layout calls for the code to sit
+// alongside text in the same raster band sent via Image.
+func RenderQR(data string, opts QROptions) (image.Image, error) {
+	code, err := qr.Encode(data, qrECLevel(opts.ECLevel), qr.Auto)
+	if err != nil {
+		return nil, fmt.Errorf("escpos: encoding QR code: %w", err)
+	}
+
+	size := opts.Size
+	if size < 2 || size > 16 {
+		size = 3
+	}
+	dim := code.Bounds().Dx() * size
+	return barcode.Scale(code, dim, dim)
+}
+
+func qrECLevel(level QRErrorCorrection) qr.ErrorCorrectionLevel {
+	switch level {
+	case QRErrorCorrectionM:
+		return qr.M
+	case QRErrorCorrectionQ:
+		return qr.Q
+	case QRErrorCorrectionH:
+		return qr.H
+	default:
+		return qr.L
+	}
+}
+
+// PDF417Options configures PDF417WithOptions and RenderPDF417.
+type PDF417Options struct {
+	// Columns and Rows request a specific symbol shape; 0 lets the encoder
+	// choose automatically. Only PDF417WithOptions uses them: RenderPDF417
+	// ignores Columns and Rows and always lets the encoder choose the shape.
+	Columns int
+	Rows    int
+	// ECLevel is the PDF417 error correction level, 0-8.
+	ECLevel int
+}
+
+// PDF417WithOptions prints a PDF417 code, letting callers choose the
+// symbol's column/row count and error correction level instead of relying
+// on the printer's defaults.
+func (p *Printer) PDF417WithOptions(data string, opts PDF417Options) error {
+	if err := p.checkTwoDCode("PDF417"); err != nil {
+		return err
+	}
+
+	const twoDbar = "\x1d\x28\x6b"
+
+	if opts.Columns > 0 {
+		if err := p.write(twoDbar + fmt.Sprintf("\x03\x00\x30\x41%c", opts.Columns)); err != nil {
+			return err
+		}
+	}
+	if opts.Rows > 0 {
+		if err := p.write(twoDbar + fmt.Sprintf("\x03\x00\x30\x42%c", opts.Rows)); err != nil {
+			return err
+		}
+	}
+	if err := p.write(twoDbar + fmt.Sprintf("\x04\x00\x30\x45\x30%c", opts.ECLevel)); err != nil {
+		return err
+	}
+
+	codePL := len(data) + 3
+	codePH := codePL / 256
+	codePL = codePL % 256
+	if err := p.write(twoDbar + rawBytes(byte(codePL), byte(codePH)) + "\x30\x50\x30" + data); err != nil {
+		return err
+	}
+
+	return p.write(twoDbar + "\x03\x00\x30\x51\x30\x0A")
+}
+
+// RenderPDF417 rasterizes a PDF417 code offline using
+// github.com/boombuler/barcode, for compositing into a larger image.
+func RenderPDF417(data string, opts PDF417Options) (image.Image, error) {
+	level := byte(opts.ECLevel)
+	code, err := pdf417.Encode(data, level)
+	if err != nil {
+		return nil, fmt.Errorf("escpos: encoding PDF417 code: %w", err)
+	}
+	return code, nil
+}
+
+// DataMatrixOptions configures RenderDataMatrix.
+type DataMatrixOptions struct {
+	// Shape requests a square (DataMatrixShapeSquare) or rectangular
+	// (DataMatrixShapeRectangle) symbol. github.com/boombuler/barcode's
+	// DataMatrix encoder always produces a square symbol today, so this
+	// is accepted but currently has no effect; it's here so callers don't
+	// need a breaking change once shape selection lands upstream.
+	Shape DataMatrixShape
+	// Size scales the rendered image; 0 uses the encoder's native size.
+	Size int
+}
+
+// DataMatrixShape selects the symbol's aspect ratio.
+type DataMatrixShape byte
+
+const (
+	DataMatrixShapeSquare DataMatrixShape = iota
+	DataMatrixShapeRectangle
+)
+
+// RenderDataMatrix rasterizes a DataMatrix code offline using
+// github.com/boombuler/barcode, for compositing into a larger image.
+func RenderDataMatrix(data string, opts DataMatrixOptions) (image.Image, error) {
+	code, err := datamatrix.Encode(data)
+	if err != nil {
+		return nil, fmt.Errorf("escpos: encoding DataMatrix code: %w", err)
+	}
+	if opts.Size <= 0 {
+		return code, nil
+	}
+	return barcode.Scale(code, opts.Size, opts.Size)
+}
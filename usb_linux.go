@@ -0,0 +1,24 @@
+//go:build linux
+
+package escpos
+
+import (
+	"fmt"
+	"os"
+)
+
+// OpenUSB opens a USB printer character device (e.g. a path returned by
+// discovery.Discover) for read/write and wires it into a Printer, keeping
+// the underlying *os.File so WriteBytes's SetWriteDeadline path works.
+func OpenUSB(path string) (*Printer, error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("escpos: couldn't open %q device: %w", path, err)
+	}
+
+	return &Printer{
+		s:          f,
+		f:          f,
+		printWidth: defaultPrintWidth,
+	}, nil
+}
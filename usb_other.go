@@ -0,0 +1,9 @@
+//go:build !linux
+
+package escpos
+
+// OpenUSB is only supported on Linux, where printers are plain character
+// devices; on other platforms it always returns ErrorNoDevicesFound.
+func OpenUSB(path string) (*Printer, error) {
+	return nil, ErrorNoDevicesFound
+}
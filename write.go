@@ -0,0 +1,29 @@
+package escpos
+
+import "time"
+
+// rawBytes builds a string directly from raw byte values. Unlike converting
+// an int/byte to a string (or interpolating it with fmt.Sprintf's %c), which
+// encodes it as the UTF-8 representation of that rune, this preserves each
+// value as a single byte — required for binary length-prefix fields such as
+// GS v 0's xL/xH/yL/yH or GS ( k's pL/pH, which break for any value >= 128
+// if rune-encoded.
+func rawBytes(bs ...byte) string {
+	return string(bs)
+}
+
+// write sends cmd to the printer, applying a write deadline when the
+// underlying stream is a file (e.g. a USB character device opened by
+// OpenUSB). It takes statusMu for the duration, the same lock
+// realtimeStatus holds while waiting on a DLE EOT n reply, so an ordinary
+// print write can't land in the middle of a status exchange.
+func (p *Printer) write(cmd string) error {
+	p.statusMu.Lock()
+	defer p.statusMu.Unlock()
+
+	if p.f != nil {
+		p.f.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	}
+	_, err := p.s.Write([]byte(cmd))
+	return err
+}